@@ -0,0 +1,164 @@
+// Command mewn scans Go source files for mewn.String/mewn.Bytes/mewn.Group
+// references, bundles the assets they point to, and writes the generated
+// mewn.go that registers them with the runtime package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/leaanthony/mewn/lib"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "mewn:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mewn <generate|verify> [flags] <files...>")
+	}
+
+	switch args[0] {
+	case "generate":
+		return runGenerate(args[1:])
+	case "verify":
+		return runVerify(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q (want generate or verify)", args[0])
+	}
+}
+
+// scan parses files, resolving every referenced asset's and group's
+// sources ready for encoding.
+func scan(files []string) ([]*lib.ReferencedAssets, error) {
+	bundles, err := lib.GetReferencedAssets(files)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		if err := bundle.ResolveSources(); err != nil {
+			return nil, err
+		}
+		for _, group := range bundle.Groups {
+			if err := group.ResolveFiles(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return bundles, nil
+}
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	compress := fs.String("compress", "raw", "asset encoding to use: raw, gzip, or auto")
+	noMetadata := fs.Bool("no-metadata", false, "skip embedding per-asset mode/mtime/sha256 metadata")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("generate: no files given")
+	}
+
+	mode, err := lib.ParseEncodingMode(*compress)
+	if err != nil {
+		return err
+	}
+
+	bundles, err := scan(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, bundle := range bundles {
+		var metas []*lib.AssetMetadata
+		if !*noMetadata {
+			metas, err = bundle.BuildMetadata()
+			if err != nil {
+				return err
+			}
+		}
+
+		generated, err := lib.Generate(bundle, mode, metas)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(generated.Path, generated.Source, 0644); err != nil {
+			return err
+		}
+
+		// The sum file is what `mewn verify` checks sources against later;
+		// skip it too under --no-metadata, since there'd be nothing to
+		// compare against anyway.
+		if metas != nil {
+			if err := writeSumFile(bundle, metas); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("verify: no files given")
+	}
+
+	bundles, err := scan(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	var stale bool
+	for _, bundle := range bundles {
+		f, err := os.Open(sumFilePath(bundle))
+		if err != nil {
+			return fmt.Errorf("verify: %w (run `mewn generate` first)", err)
+		}
+		metas, err := lib.ReadSumFile(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("verify: %w", err)
+		}
+
+		for _, result := range lib.Verify(metas, bundle) {
+			if result.Err != nil {
+				return fmt.Errorf("verify: %q: %w", result.Name, result.Err)
+			}
+			if result.Stale {
+				stale = true
+				fmt.Fprintf(os.Stderr, "mewn: %s is stale\n", result.Name)
+			}
+		}
+	}
+
+	if stale {
+		return fmt.Errorf("one or more assets are stale; run `mewn generate` again")
+	}
+	return nil
+}
+
+func writeSumFile(bundle *lib.ReferencedAssets, metas []*lib.AssetMetadata) error {
+	f, err := os.Create(sumFilePath(bundle))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return lib.WriteSumFile(f, metas)
+}
+
+// sumFilePath is where generate writes (and verify reads) the sha256
+// digests for bundle's assets, named after the go.sum convention this
+// module's own build already uses.
+func sumFilePath(bundle *lib.ReferencedAssets) string {
+	return filepath.Join(bundle.BaseDir, "mewn.sum")
+}
@@ -0,0 +1,213 @@
+package mewn
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AssetGroup is a named collection of files embedded by mewn.Group(...),
+// exposed as an fs.FS (and, via HTTPFS, an http.FileSystem). Generated code
+// builds one of these per group via NewGroup.
+type AssetGroup struct {
+	files    map[string]*asset          // slash-separated relative path -> asset
+	children map[string]map[string]bool // slash-separated dir path ("." for root) -> immediate child names
+}
+
+// NewGroup builds an AssetGroup from the data generated code registers for
+// each file in a mewn.Group(...). compressed may be nil if none of the
+// files are compressed. It is not meant to be called directly by user
+// code; generated files pass the result to RegisterGroup.
+func NewGroup(files map[string][]byte, compressed map[string]bool) *AssetGroup {
+	g := &AssetGroup{
+		files:    make(map[string]*asset, len(files)),
+		children: make(map[string]map[string]bool),
+	}
+	for name, data := range files {
+		name = path.Clean(name)
+		g.files[name] = &asset{data: data, compressed: compressed[name]}
+		g.registerPath(name)
+	}
+	return g
+}
+
+// registerPath walks name's ancestor directories, recording each one's
+// immediate children so ReadDir can synthesize directory listings.
+func (g *AssetGroup) registerPath(name string) {
+	for {
+		dir := path.Dir(name)
+		base := path.Base(name)
+		if g.children[dir] == nil {
+			g.children[dir] = make(map[string]bool)
+		}
+		g.children[dir][base] = true
+		if dir == "." {
+			return
+		}
+		name = dir
+	}
+}
+
+// FS returns the group as an fs.FS, implementing fs.ReadDirFS, fs.StatFS,
+// and fs.SubFS.
+func (g *AssetGroup) FS() fs.FS { return g }
+
+// HTTPFS returns the group as an http.FileSystem, suitable for
+// http.FileServer.
+func (g *AssetGroup) HTTPFS() http.FileSystem { return http.FS(g) }
+
+// Open implements fs.FS.
+func (g *AssetGroup) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	name = path.Clean(name)
+
+	if name == "." || g.children[name] != nil {
+		return g.openDir(name)
+	}
+
+	a, ok := g.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return a.open(name)
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (g *AssetGroup) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := g.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	dir, ok := f.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return dir.ReadDir(-1)
+}
+
+// Stat implements fs.StatFS.
+func (g *AssetGroup) Stat(name string) (fs.FileInfo, error) {
+	f, err := g.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// Sub implements fs.SubFS.
+func (g *AssetGroup) Sub(dir string) (fs.FS, error) {
+	dir = path.Clean(dir)
+	if dir == "." {
+		return g, nil
+	}
+	if g.children[dir] == nil {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrNotExist}
+	}
+
+	sub := &AssetGroup{files: make(map[string]*asset), children: make(map[string]map[string]bool)}
+	prefix := dir + "/"
+	for name, a := range g.files {
+		if rel := strings.TrimPrefix(name, prefix); rel != name {
+			sub.files[rel] = a
+			sub.registerPath(rel)
+		}
+	}
+	return sub, nil
+}
+
+// Bytes returns the contents of the named file within the group, panicking
+// if it doesn't exist or fails to decompress. Mirrors the package-level
+// Bytes, which panics for the same reason: a missing or broken group file
+// means the binary was built from a stale or broken generated file, not
+// something callers can recover from. MustBytes is kept as an alias for
+// call sites that prefer to spell that out.
+func (g *AssetGroup) Bytes(name string) []byte {
+	f, err := g.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// MustBytes is an alias for Bytes.
+func (g *AssetGroup) MustBytes(name string) []byte {
+	return g.Bytes(name)
+}
+
+// String returns the contents of the named file within the group as a
+// string. See Bytes for the panic behavior.
+func (g *AssetGroup) String(name string) string {
+	return string(g.Bytes(name))
+}
+
+// MustString is an alias for String.
+func (g *AssetGroup) MustString(name string) string {
+	return g.String(name)
+}
+
+func (g *AssetGroup) openDir(name string) (fs.File, error) {
+	children := g.children[name]
+	entries := make([]fs.DirEntry, 0, len(children))
+	for base := range children {
+		full := base
+		if name != "." {
+			full = name + "/" + base
+		}
+		entries = append(entries, dirEntry{fileInfo{name: base, isDir: g.children[full] != nil}})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return &openDirFile{name: name, entries: entries}, nil
+}
+
+// open returns an fs.File for a resolved (and decompressed-on-demand) asset.
+func (a *asset) open(name string) (fs.File, error) {
+	data, err := a.resolve()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &openFile{
+		name: path.Base(name),
+		data: data,
+	}, nil
+}
+
+// fileInfo is a minimal fs.FileInfo for embedded files and synthesized
+// directories.
+type fileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (fi fileInfo) Name() string { return fi.name }
+func (fi fileInfo) Size() int64  { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.isDir }
+func (fi fileInfo) Sys() interface{}   { return nil }
+
+// dirEntry adapts a fileInfo to fs.DirEntry.
+type dirEntry struct{ fi fileInfo }
+
+func (d dirEntry) Name() string               { return d.fi.name }
+func (d dirEntry) IsDir() bool                { return d.fi.isDir }
+func (d dirEntry) Type() fs.FileMode          { return d.fi.Mode().Type() }
+func (d dirEntry) Info() (fs.FileInfo, error) { return d.fi, nil }
@@ -0,0 +1,65 @@
+package mewn
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+)
+
+// openFile is the fs.File returned by AssetGroup.Open for a regular file.
+type openFile struct {
+	name string
+	data []byte
+	r    *bytes.Reader
+}
+
+func (f *openFile) reader() *bytes.Reader {
+	if f.r == nil {
+		f.r = bytes.NewReader(f.data)
+	}
+	return f.r
+}
+
+func (f *openFile) Read(p []byte) (int, error) { return f.reader().Read(p) }
+func (f *openFile) Seek(offset int64, whence int) (int64, error) {
+	return f.reader().Seek(offset, whence)
+}
+func (f *openFile) Close() error { return nil }
+func (f *openFile) Stat() (fs.FileInfo, error) {
+	return fileInfo{name: f.name, size: int64(len(f.data))}, nil
+}
+
+// openDirFile is the fs.ReadDirFile returned by AssetGroup.Open for a directory.
+type openDirFile struct {
+	name    string
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *openDirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *openDirFile) Close() error { return nil }
+
+func (d *openDirFile) Stat() (fs.FileInfo, error) {
+	return fileInfo{name: d.name, isDir: true}, nil
+}
+
+func (d *openDirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := len(d.entries) - d.offset
+	if n <= 0 {
+		entries := d.entries[d.offset:]
+		d.offset = len(d.entries)
+		return entries, nil
+	}
+	if remaining == 0 {
+		return nil, io.EOF
+	}
+	if n > remaining {
+		n = remaining
+	}
+	entries := d.entries[d.offset : d.offset+n]
+	d.offset += n
+	return entries, nil
+}
@@ -0,0 +1,48 @@
+package mewn
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	groupsMu sync.RWMutex
+	groups   = map[string]*AssetGroup{}
+)
+
+// RegisterGroup makes a generated AssetGroup available under localPath, the
+// same string literal passed to Group(...) in user source. It is called
+// from the init() functions of generated files and is not meant to be
+// called directly by user code.
+func RegisterGroup(localPath string, g *AssetGroup) {
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+	groups[localPath] = g
+}
+
+// GroupOption configures a call to Group(...); see Include and Exclude.
+type GroupOption func()
+
+// Include restricts a Group to files matching any of the given glob
+// patterns. mewn's static scanner (see lib.GetReferencedAssets) reads these
+// patterns directly out of the source to decide what to bundle; at runtime
+// Include is a no-op; the patterns have already been applied by the
+// generator by the time Group returns.
+func Include(patterns ...string) GroupOption { return func() {} }
+
+// Exclude is the complement of Include; see its documentation.
+func Exclude(patterns ...string) GroupOption { return func() {} }
+
+// Group returns the AssetGroup that the mewn generator produced for
+// localPath - the same string (and, optionally, Include/Exclude filters)
+// passed here. It panics if the generator hasn't registered that path,
+// which normally means the code hasn't been run through `mewn generate` yet.
+func Group(localPath string, opts ...GroupOption) *AssetGroup {
+	groupsMu.RLock()
+	g, ok := groups[localPath]
+	groupsMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("mewn: no generated group for %q (did you run the mewn generator?)", localPath))
+	}
+	return g
+}
@@ -0,0 +1,30 @@
+package mewn
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func TestGroupReturnsRegisteredGroup(t *testing.T) {
+	want := NewGroup(map[string][]byte{"index.html": []byte("hi")}, nil)
+	RegisterGroup("./web", want)
+
+	got := Group("./web", Include("*.html"), Exclude("*.map"))
+	if got != want {
+		t.Fatalf("Group returned a different *AssetGroup than was registered")
+	}
+
+	data, err := fs.ReadFile(got.FS(), "index.html")
+	if err != nil || string(data) != "hi" {
+		t.Fatalf("unexpected contents: %q, err %v", data, err)
+	}
+}
+
+func TestGroupPanicsWhenUnregistered(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Group to panic for an unregistered path")
+		}
+	}()
+	Group("./never-registered")
+}
@@ -0,0 +1,183 @@
+package mewn
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func newTestGroup() *AssetGroup {
+	return NewGroup(map[string][]byte{
+		"index.html":           []byte("<h1>hi</h1>"),
+		"css/style.css":        []byte("body{}"),
+		"css/vendor/reset.css": []byte("* {}"),
+	}, nil)
+}
+
+func TestGroupFSReadFile(t *testing.T) {
+	g := newTestGroup()
+
+	f, err := g.FS().Open("index.html")
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != "<h1>hi</h1>" {
+		t.Fatalf("got %q, want %q", data, "<h1>hi</h1>")
+	}
+}
+
+func TestGroupBytesAndString(t *testing.T) {
+	g := newTestGroup()
+
+	if got := g.String("index.html"); got != "<h1>hi</h1>" {
+		t.Fatalf("String(%q) = %q, want %q", "index.html", got, "<h1>hi</h1>")
+	}
+	if got := string(g.Bytes("css/style.css")); got != "body{}" {
+		t.Fatalf("Bytes(%q) = %q, want %q", "css/style.css", got, "body{}")
+	}
+}
+
+func TestGroupBytesPanicsOnMissingFile(t *testing.T) {
+	g := newTestGroup()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Bytes to panic for a missing file")
+		}
+	}()
+	g.Bytes("does-not-exist")
+}
+
+func TestGroupFSReadDir(t *testing.T) {
+	g := newTestGroup()
+
+	entries, err := fs.ReadDir(g.FS(), ".")
+	if err != nil {
+		t.Fatalf("ReadDir returned an error: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	want := []string{"css", "index.html"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i, name := range want {
+		if names[i] != name {
+			t.Errorf("entry %d = %q, want %q", i, names[i], name)
+		}
+	}
+}
+
+func TestGroupFSReadDirPaginated(t *testing.T) {
+	g := newTestGroup()
+
+	f, err := g.FS().Open(".")
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+	defer f.Close()
+
+	dir, ok := f.(fs.ReadDirFile)
+	if !ok {
+		t.Fatalf("expected the root to open as an fs.ReadDirFile")
+	}
+
+	var names []string
+	for {
+		entries, err := dir.ReadDir(1)
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatalf("ReadDir returned an unexpected error: %v", err)
+			}
+			break
+		}
+		if len(names) > 10 {
+			t.Fatalf("ReadDir(1) never reached io.EOF")
+		}
+	}
+
+	sort.Strings(names)
+	want := []string{"css", "index.html"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i, name := range want {
+		if names[i] != name {
+			t.Errorf("entry %d = %q, want %q", i, names[i], name)
+		}
+	}
+}
+
+func TestGroupFSStat(t *testing.T) {
+	g := newTestGroup()
+
+	info, err := fs.Stat(g.FS(), "css/style.css")
+	if err != nil {
+		t.Fatalf("Stat returned an error: %v", err)
+	}
+	if info.IsDir() {
+		t.Fatalf("expected css/style.css to not be a directory")
+	}
+	if info.Size() != int64(len("body{}")) {
+		t.Fatalf("expected size %d, got %d", len("body{}"), info.Size())
+	}
+}
+
+func TestGroupFSSub(t *testing.T) {
+	g := newTestGroup()
+
+	sub, err := fs.Sub(g.FS(), "css")
+	if err != nil {
+		t.Fatalf("Sub returned an error: %v", err)
+	}
+
+	data, err := fs.ReadFile(sub, "style.css")
+	if err != nil {
+		t.Fatalf("failed to read from sub FS: %v", err)
+	}
+	if string(data) != "body{}" {
+		t.Fatalf("got %q, want %q", data, "body{}")
+	}
+
+	if _, err := fs.Stat(sub, "vendor/reset.css"); err != nil {
+		t.Fatalf("expected nested sub-directory to carry over: %v", err)
+	}
+}
+
+func TestGroupHTTPFS(t *testing.T) {
+	g := newTestGroup()
+
+	server := httptest.NewServer(http.FileServer(g.HTTPFS()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/index.html")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+	if string(body) != "<h1>hi</h1>" {
+		t.Fatalf("got %q, want %q", body, "<h1>hi</h1>")
+	}
+}
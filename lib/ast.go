@@ -5,6 +5,8 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -16,6 +18,11 @@ type ReferencedAsset struct {
 	Name      string
 	AssetPath string
 	Group     *Group
+
+	// SourcePath and Info are populated during the packing pass (see
+	// ResolveSources), not by GetReferencedAssets itself.
+	SourcePath string
+	Info       os.FileInfo
 }
 
 // Group holds information relating to a group
@@ -23,6 +30,13 @@ type Group struct {
 	Name      string
 	LocalPath string
 	FullPath  string
+	Include   []string
+	Exclude   []string
+
+	// Files is the list of files under FullPath that passed Include/Exclude,
+	// relative to FullPath and slash-separated. It is populated by
+	// ResolveFiles, not by GetReferencedAssets itself.
+	Files []string
 }
 
 // ReferencedAssets is a collection of assets referenced from a file
@@ -45,6 +59,40 @@ func (r *ReferencedAssets) HasAsset(name string) bool {
 	return false
 }
 
+// parseMewnCall inspects a resolved mewn call and, if it refers to
+// mewn itself or to a previously registered group, records the
+// resulting group or asset against this bundle. name is the identifier
+// the call's result is bound to, and is empty for discarded calls (e.g.
+// a bare `mewn.Bytes("x")` statement inside an init()).
+func (r *ReferencedAssets) parseMewnCall(name string, call *CallStmt, groups map[string]*Group, filename string) error {
+	if call.Obj == "mewn" {
+		switch call.Method {
+		case "Group":
+			baseDir := filepath.Dir(filename)
+			fullPath, err := filepath.Abs(filepath.Join(baseDir, call.Path))
+			if err != nil {
+				return err
+			}
+			thisGroup := &Group{Name: name, LocalPath: call.Path, FullPath: fullPath, Include: call.Include, Exclude: call.Exclude}
+			r.Groups = append(r.Groups, thisGroup)
+			groups[name] = thisGroup
+		case "String", "MustString", "Bytes", "MustBytes":
+			newAsset := &ReferencedAsset{Name: call.Path, Group: nil, AssetPath: call.Path}
+			r.Assets = append(r.Assets, newAsset)
+		default:
+			return fmt.Errorf("unknown call to mewn.%s", call.Method)
+		}
+		return nil
+	}
+
+	// Check if we have a call on a group
+	if group, exists := groups[call.Obj]; exists {
+		newAsset := &ReferencedAsset{Name: call.Path, Group: group, AssetPath: call.Path}
+		r.Assets = append(r.Assets, newAsset)
+	}
+	return nil
+}
+
 // GetReferencedAssets gets a list of referenced assets from the AST
 func GetReferencedAssets(filenames []string) ([]*ReferencedAssets, error) {
 
@@ -53,11 +101,30 @@ func GetReferencedAssets(filenames []string) ([]*ReferencedAssets, error) {
 
 	groups := make(map[string]*Group)
 
+	// Type-check the package(s) up front so non-literal asset paths (consts,
+	// path.Join over consts, ...) can be constant-folded. If this fails -
+	// e.g. the package doesn't type-check - we silently fall back to the
+	// literal-only parse below on a per-file basis.
+	typedFiles, typedInfos, _ := loadTypeInfo(filenames)
+
 	for _, filename := range filenames {
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, filename, nil, parser.AllErrors)
-		if err != nil {
-			return nil, err
+		var node *ast.File
+		var info *types.Info
+
+		absFilename, err := filepath.Abs(filename)
+		if err == nil {
+			if typedNode, ok := typedFiles[absFilename]; ok {
+				node = typedNode
+				info = typedInfos[absFilename]
+			}
+		}
+
+		if node == nil {
+			fset := token.NewFileSet()
+			node, err = parser.ParseFile(fset, filename, nil, parser.AllErrors)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		var packageName string
@@ -70,6 +137,8 @@ func GetReferencedAssets(filenames []string) ([]*ReferencedAssets, error) {
 			assetMap[baseDir] = thisAssetBundle
 		}
 
+		var walkErr error
+
 		ast.Inspect(node, func(node ast.Node) bool {
 			switch x := node.(type) {
 			case *ast.File:
@@ -77,40 +146,63 @@ func GetReferencedAssets(filenames []string) ([]*ReferencedAssets, error) {
 				thisAssetBundle.PackageName = packageName
 
 			case *ast.AssignStmt:
-				thisAsset := ParseAssignment(x)
+				thisAsset := ParseAssignment(x, info)
 				if thisAsset != nil {
-					objName := thisAsset.RHS.Obj
-					if objName == "mewn" {
-						switch thisAsset.RHS.Method {
-						case "Group":
-							baseDir := filepath.Dir(filename)
-							fullPath, err := filepath.Abs(filepath.Join(baseDir, thisAsset.RHS.Path))
-							if err != nil {
-								return false
-							}
-							thisGroup := &Group{Name: thisAsset.LHS, LocalPath: thisAsset.RHS.Path, FullPath: fullPath}
-							thisAssetBundle.Groups = append(thisAssetBundle.Groups, thisGroup)
-							groups[thisAsset.LHS] = thisGroup
-						case "String", "MustString", "Bytes", "MustBytes":
-							newAsset := &ReferencedAsset{Name: thisAsset.RHS.Path, Group: nil, AssetPath: thisAsset.RHS.Path}
-							thisAssetBundle.Assets = append(thisAssetBundle.Assets, newAsset)
-						default:
-							err = fmt.Errorf("unknown call to mewn.%s", thisAsset.RHS.Method)
-							return false
+					if err := thisAssetBundle.parseMewnCall(thisAsset.LHS, thisAsset.RHS, groups, filename); err != nil {
+						walkErr = err
+						return false
+					}
+				}
+
+			case *ast.GenDecl:
+				// Covers both `var x = mewn.String(...)` and grouped
+				// `var ( x = mewn.String(...) )` blocks.
+				for _, spec := range x.Specs {
+					valueSpec, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, name := range valueSpec.Names {
+						if i >= len(valueSpec.Values) {
+							break
 						}
-					} else {
-						// Check if we have a call on a group
-						group, exists := groups[objName]
-						if exists {
-							// We have a group call!
-							newAsset := &ReferencedAsset{Name: thisAsset.RHS.Path, Group: group, AssetPath: thisAsset.RHS.Path}
-							thisAssetBundle.Assets = append(thisAssetBundle.Assets, newAsset)
+						callExpr, ok := valueSpec.Values[i].(*ast.CallExpr)
+						if !ok {
+							continue
+						}
+						call := ParseCallExprTyped(callExpr, info)
+						if call == nil {
+							continue
+						}
+						if err := thisAssetBundle.parseMewnCall(name.String(), call, groups, filename); err != nil {
+							walkErr = err
+							return false
 						}
 					}
 				}
+
+			case *ast.ExprStmt:
+				// A bare call whose result is discarded, e.g. inside init().
+				callExpr, ok := x.X.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				call := ParseCallExprTyped(callExpr, info)
+				if call == nil {
+					return true
+				}
+				if err := thisAssetBundle.parseMewnCall("", call, groups, filename); err != nil {
+					walkErr = err
+					return false
+				}
 			}
 			return true
 		})
+
+		if walkErr != nil {
+			return nil, walkErr
+		}
+
 		result = append(result, thisAssetBundle)
 	}
 	return result, nil
@@ -126,8 +218,11 @@ func (a *AssignStmt) String() string {
 	return fmt.Sprintf("%s = %s", a.LHS, a.RHS)
 }
 
-// ParseAssignment parses an assignment statement
-func ParseAssignment(astmt *ast.AssignStmt) *AssignStmt {
+// ParseAssignment parses an assignment statement. info is the type-checker
+// result for the enclosing file, if available, and is used to resolve
+// non-literal asset paths; it may be nil, in which case only literal paths
+// are recognised.
+func ParseAssignment(astmt *ast.AssignStmt, info *types.Info) *AssignStmt {
 	var lhs string
 	var result *AssignStmt
 
@@ -137,7 +232,7 @@ func ParseAssignment(astmt *ast.AssignStmt) *AssignStmt {
 
 	if len(astmt.Rhs) == 1 && reflect.TypeOf(astmt.Rhs[0]).String() == "*ast.CallExpr" {
 		t := astmt.Rhs[0].(*ast.CallExpr)
-		call := ParseCallExpr(t)
+		call := ParseCallExprTyped(t, info)
 		if call != nil {
 			result = &AssignStmt{LHS: lhs, RHS: call}
 		}
@@ -151,40 +246,118 @@ type CallStmt struct {
 	Obj    string
 	Method string
 	Path   string
+
+	// Include and Exclude hold the glob patterns passed to mewn.Include(...)
+	// and mewn.Exclude(...) when this call is a mewn.Group(...) with filter
+	// arguments.
+	Include []string
+	Exclude []string
 }
 
 func (c *CallStmt) String() string {
 	return fmt.Sprintf("{ obj: '%s', method: '%s', path: '%s' }", c.Obj, c.Method, c.Path)
 }
 
-// ParseCallExpr parses a call expression for mewn related statements
-func ParseCallExpr(callstmt *ast.CallExpr) *CallStmt {
-	var result *CallStmt
+// parseSelectorCall extracts the `obj.Method(args...)` shape common to
+// every mewn call (mewn.String(...), someGroup.Bytes(...),
+// mewn.Group(path, mewn.Include(...), ...)). It returns ok=false for
+// anything that doesn't match, including calls with no arguments at all.
+func parseSelectorCall(callstmt *ast.CallExpr) (obj, method string, args []ast.Expr, ok bool) {
+	if len(callstmt.Args) < 1 {
+		return "", "", nil, false
+	}
 
-	if len(callstmt.Args) != 1 {
-		return nil
+	fn, isSelector := callstmt.Fun.(*ast.SelectorExpr)
+	if !isSelector {
+		return "", "", nil, false
+	}
+
+	if reflect.TypeOf(fn.X).String() != "*ast.Ident" {
+		return "", "", nil, false
+	}
+	if reflect.TypeOf(fn.Sel).String() != "*ast.Ident" {
+		return "", "", nil, false
 	}
 
-	switch fn := callstmt.Fun.(type) {
-	case *ast.SelectorExpr:
-		if reflect.TypeOf(fn.X).String() != "*ast.Ident" {
-			return nil
+	return fn.X.(*ast.Ident).String(), fn.Sel.String(), callstmt.Args, true
+}
+
+// parseFilterArgs recognises mewn.Include(...)/mewn.Exclude(...) calls
+// among a mewn.Group(...) call's trailing arguments, returning the glob
+// patterns they specify.
+func parseFilterArgs(args []ast.Expr) (include, exclude []string) {
+	for _, arg := range args {
+		call, isCall := arg.(*ast.CallExpr)
+		if !isCall {
+			continue
+		}
+		sel, isSelector := call.Fun.(*ast.SelectorExpr)
+		if !isSelector {
+			continue
+		}
+		ident, isIdent := sel.X.(*ast.Ident)
+		if !isIdent || ident.Name != "mewn" {
+			continue
 		}
-		obj := fn.X.(*ast.Ident).String()
 
-		if reflect.TypeOf(fn.Sel).String() != "*ast.Ident" {
-			return nil
+		var patterns []string
+		for _, patternArg := range call.Args {
+			lit, isBasicLit := patternArg.(*ast.BasicLit)
+			if !isBasicLit {
+				continue
+			}
+			patterns = append(patterns, strings.Replace(lit.Value, "\"", "", -1))
 		}
-		fnCallName := fn.Sel.String()
 
-		if reflect.TypeOf(callstmt.Args[0]).String() != "*ast.BasicLit" {
-			return nil
+		switch sel.Sel.Name {
+		case "Include":
+			include = append(include, patterns...)
+		case "Exclude":
+			exclude = append(exclude, patterns...)
 		}
+	}
+	return include, exclude
+}
 
-		assetPath := strings.Replace(callstmt.Args[0].(*ast.BasicLit).Value, "\"", "", -1)
+// ParseCallExpr parses a call expression for mewn related statements. Only
+// a literal string path argument is recognised; use ParseCallExprTyped to
+// also resolve constants and path.Join/filepath.Join expressions.
+func ParseCallExpr(callstmt *ast.CallExpr) *CallStmt {
+	obj, method, args, ok := parseSelectorCall(callstmt)
+	if !ok {
+		return nil
+	}
+
+	lit, isBasicLit := args[0].(*ast.BasicLit)
+	if !isBasicLit {
+		return nil
+	}
 
-		result = &CallStmt{Obj: obj, Method: fnCallName, Path: assetPath}
+	assetPath := strings.Replace(lit.Value, "\"", "", -1)
+	include, exclude := parseFilterArgs(args[1:])
+	return &CallStmt{Obj: obj, Method: method, Path: assetPath, Include: include, Exclude: exclude}
+}
 
+// ParseCallExprTyped is like ParseCallExpr but additionally resolves a
+// non-literal path argument - constants, string concatenation, and
+// path.Join/filepath.Join calls over constants - using the type-checker
+// results in info. It falls back to the literal-only behaviour of
+// ParseCallExpr when info is nil or the argument can't be constant-folded.
+func ParseCallExprTyped(callstmt *ast.CallExpr, info *types.Info) *CallStmt {
+	if call := ParseCallExpr(callstmt); call != nil {
+		return call
 	}
-	return result
+
+	obj, method, args, ok := parseSelectorCall(callstmt)
+	if !ok {
+		return nil
+	}
+
+	assetPath, ok := resolveConstantString(info, args[0])
+	if !ok {
+		return nil
+	}
+
+	include, exclude := parseFilterArgs(args[1:])
+	return &CallStmt{Obj: obj, Method: method, Path: assetPath, Include: include, Exclude: exclude}
 }
@@ -0,0 +1,89 @@
+package lib
+
+import "testing"
+
+func TestGetReferencedAssetsFromDeclarations(t *testing.T) {
+	bundles, err := GetReferencedAssets([]string{"testdata/decls.go"})
+	if err != nil {
+		t.Fatalf("GetReferencedAssets returned an error: %v", err)
+	}
+
+	if len(bundles) != 1 {
+		t.Fatalf("expected 1 bundle, got %d", len(bundles))
+	}
+
+	bundle := bundles[0]
+
+	wantAssets := []string{"style.css", "index.html", "favicon.ico", "warmup.bin"}
+	for _, name := range wantAssets {
+		if !bundle.HasAsset(name) {
+			t.Errorf("expected asset %q to be referenced, it was not", name)
+		}
+	}
+
+	if len(bundle.Assets) != len(wantAssets) {
+		t.Errorf("expected %d assets, got %d: %+v", len(wantAssets), len(bundle.Assets), bundle.Assets)
+	}
+
+	if len(bundle.Groups) != 1 || bundle.Groups[0].Name != "webGroup" {
+		t.Fatalf("expected a single group named webGroup, got %+v", bundle.Groups)
+	}
+
+	for _, asset := range bundle.Assets {
+		if asset.Name == "index.html" && asset.Group == nil {
+			t.Errorf("expected index.html to be attached to webGroup")
+		}
+	}
+}
+
+func TestGetReferencedAssetsResolvesNonLiteralPath(t *testing.T) {
+	bundles, err := GetReferencedAssets([]string{"testdata/typed_decls.go"})
+	if err != nil {
+		t.Fatalf("GetReferencedAssets returned an error: %v", err)
+	}
+
+	if len(bundles) != 1 {
+		t.Fatalf("expected 1 bundle, got %d", len(bundles))
+	}
+
+	// assetsDir + "/index.html" only resolves to "web/dist/index.html" via
+	// the packages.Load-backed typed pass in loadTypeInfo/resolveConstantString
+	// - it's not a literal, so this exercises that plumbing end-to-end rather
+	// than just the in-memory snippets in resolve_test.go.
+	if !bundles[0].HasAsset("web/dist/index.html") {
+		t.Fatalf("expected the constant-folded path to be resolved, got %+v", bundles[0].Assets)
+	}
+}
+
+func TestGetReferencedAssetsGroupFilters(t *testing.T) {
+	bundles, err := GetReferencedAssets([]string{"testdata/group_filters.go"})
+	if err != nil {
+		t.Fatalf("GetReferencedAssets returned an error: %v", err)
+	}
+
+	if len(bundles) != 1 || len(bundles[0].Groups) != 1 {
+		t.Fatalf("expected 1 bundle with 1 group, got %+v", bundles)
+	}
+
+	group := bundles[0].Groups[0]
+	wantInclude := []string{"*.html", "*.css", "*.js"}
+	wantExclude := []string{"*.map", "node_modules/**"}
+
+	if len(group.Include) != len(wantInclude) {
+		t.Fatalf("expected Include %v, got %v", wantInclude, group.Include)
+	}
+	for i, pattern := range wantInclude {
+		if group.Include[i] != pattern {
+			t.Errorf("Include[%d] = %q, want %q", i, group.Include[i], pattern)
+		}
+	}
+
+	if len(group.Exclude) != len(wantExclude) {
+		t.Fatalf("expected Exclude %v, got %v", wantExclude, group.Exclude)
+	}
+	for i, pattern := range wantExclude {
+		if group.Exclude[i] != pattern {
+			t.Errorf("Exclude[%d] = %q, want %q", i, group.Exclude[i], pattern)
+		}
+	}
+}
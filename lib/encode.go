@@ -0,0 +1,89 @@
+package lib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+)
+
+// EncodingMode controls how an asset's contents are stored in the
+// generated Go file.
+type EncodingMode int
+
+const (
+	// EncodingRaw stores the asset's bytes verbatim.
+	EncodingRaw EncodingMode = iota
+	// EncodingGzip always gzip-compresses the asset's bytes.
+	EncodingGzip
+	// EncodingAuto gzip-compresses the asset only if doing so shrinks it
+	// by at least autoCompressMargin; otherwise it falls back to raw.
+	EncodingAuto
+)
+
+// autoCompressMargin is the minimum fractional size reduction EncodingAuto
+// requires before it keeps the compressed form.
+const autoCompressMargin = 0.1
+
+// EncodedAsset is the result of encoding a single asset's contents for
+// embedding in the generated Go file.
+type EncodedAsset struct {
+	Data         []byte
+	Compressed   bool
+	OriginalSize int
+}
+
+// EncodeAsset encodes data according to mode, returning the bytes to embed
+// and whether they ended up compressed.
+func EncodeAsset(data []byte, mode EncodingMode) (*EncodedAsset, error) {
+	originalSize := len(data)
+
+	if mode == EncodingRaw {
+		return &EncodedAsset{Data: data, OriginalSize: originalSize}, nil
+	}
+
+	compressed, err := gzipBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == EncodingAuto && !worthCompressing(originalSize, len(compressed)) {
+		return &EncodedAsset{Data: data, OriginalSize: originalSize}, nil
+	}
+
+	return &EncodedAsset{Data: compressed, Compressed: true, OriginalSize: originalSize}, nil
+}
+
+// worthCompressing reports whether shrinking originalSize down to
+// compressedSize clears autoCompressMargin.
+func worthCompressing(originalSize, compressedSize int) bool {
+	if originalSize == 0 {
+		return false
+	}
+	return float64(originalSize-compressedSize)/float64(originalSize) >= autoCompressMargin
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseEncodingMode maps a --compress flag value to an EncodingMode.
+func ParseEncodingMode(flag string) (EncodingMode, error) {
+	switch flag {
+	case "", "raw":
+		return EncodingRaw, nil
+	case "gzip":
+		return EncodingGzip, nil
+	case "auto":
+		return EncodingAuto, nil
+	default:
+		return EncodingRaw, fmt.Errorf("unknown compression mode %q (want raw, gzip, or auto)", flag)
+	}
+}
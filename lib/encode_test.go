@@ -0,0 +1,98 @@
+package lib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestEncodeAssetRaw(t *testing.T) {
+	data := []byte("hello world")
+	encoded, err := EncodeAsset(data, EncodingRaw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded.Compressed {
+		t.Fatalf("expected EncodingRaw to never compress")
+	}
+	if !bytes.Equal(encoded.Data, data) {
+		t.Fatalf("expected data to be stored verbatim")
+	}
+	if encoded.OriginalSize != len(data) {
+		t.Fatalf("expected OriginalSize %d, got %d", len(data), encoded.OriginalSize)
+	}
+}
+
+func TestEncodeAssetGzip(t *testing.T) {
+	data := []byte(strings.Repeat("a", 4096))
+	encoded, err := EncodeAsset(data, EncodingGzip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !encoded.Compressed {
+		t.Fatalf("expected EncodingGzip to always compress")
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(encoded.Data))
+	if err != nil {
+		t.Fatalf("encoded data is not valid gzip: %v", err)
+	}
+	defer r.Close()
+	roundTripped, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to decompress: %v", err)
+	}
+	if !bytes.Equal(roundTripped, data) {
+		t.Fatalf("round-tripped data does not match original")
+	}
+}
+
+func TestEncodeAssetAutoSkipsIncompressibleData(t *testing.T) {
+	// Too small to clear the compression margin once gzip overhead is added.
+	data := []byte("x")
+	encoded, err := EncodeAsset(data, EncodingAuto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded.Compressed {
+		t.Fatalf("expected EncodingAuto to leave tiny/incompressible data raw")
+	}
+	if !bytes.Equal(encoded.Data, data) {
+		t.Fatalf("expected data to be stored verbatim")
+	}
+}
+
+func TestEncodeAssetAutoCompressesWhenItHelps(t *testing.T) {
+	data := []byte(strings.Repeat("compress me please ", 200))
+	encoded, err := EncodeAsset(data, EncodingAuto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !encoded.Compressed {
+		t.Fatalf("expected EncodingAuto to compress highly-redundant data")
+	}
+}
+
+func TestParseEncodingMode(t *testing.T) {
+	cases := map[string]EncodingMode{
+		"":     EncodingRaw,
+		"raw":  EncodingRaw,
+		"gzip": EncodingGzip,
+		"auto": EncodingAuto,
+	}
+	for flag, want := range cases {
+		got, err := ParseEncodingMode(flag)
+		if err != nil {
+			t.Fatalf("ParseEncodingMode(%q) returned error: %v", flag, err)
+		}
+		if got != want {
+			t.Errorf("ParseEncodingMode(%q) = %v, want %v", flag, got, want)
+		}
+	}
+
+	if _, err := ParseEncodingMode("bogus"); err == nil {
+		t.Fatalf("expected an error for an unknown compression mode")
+	}
+}
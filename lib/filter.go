@@ -0,0 +1,26 @@
+package lib
+
+import "github.com/bmatcuk/doublestar/v4"
+
+// MatchesFilters reports whether relPath (a slash-separated path relative to
+// the group's root) should be bundled, given the group's include/exclude
+// patterns. Exclude takes precedence over include, and an empty include
+// list matches everything. Patterns support doublestar globs (e.g. "**").
+func (g *Group) MatchesFilters(relPath string) bool {
+	for _, pattern := range g.Exclude {
+		if matched, _ := doublestar.Match(pattern, relPath); matched {
+			return false
+		}
+	}
+
+	if len(g.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range g.Include {
+		if matched, _ := doublestar.Match(pattern, relPath); matched {
+			return true
+		}
+	}
+	return false
+}
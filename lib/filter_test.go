@@ -0,0 +1,36 @@
+package lib
+
+import "testing"
+
+func TestGroupMatchesFilters(t *testing.T) {
+	g := &Group{
+		Include: []string{"*.html", "*.css", "*.js"},
+		Exclude: []string{"*.map", "node_modules/**"},
+	}
+
+	cases := map[string]bool{
+		"index.html":                true,
+		"style.css":                 true,
+		"app.js":                    true,
+		"app.js.map":                false,
+		"node_modules/foo/index.js": false,
+		"README.md":                 false,
+	}
+
+	for path, want := range cases {
+		if got := g.MatchesFilters(path); got != want {
+			t.Errorf("MatchesFilters(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestGroupMatchesFiltersNoIncludeMatchesEverything(t *testing.T) {
+	g := &Group{Exclude: []string{"*.map"}}
+
+	if !g.MatchesFilters("index.html") {
+		t.Errorf("expected a group with no Include patterns to match anything not excluded")
+	}
+	if g.MatchesFilters("app.js.map") {
+		t.Errorf("expected app.js.map to be excluded")
+	}
+}
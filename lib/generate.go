@@ -0,0 +1,70 @@
+package lib
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+)
+
+// GeneratedFile is the rendered output of Generate, ready to be written to
+// disk by the caller.
+type GeneratedFile struct {
+	// Path is where the generated source should be written: mewn.go next
+	// to the package that referenced the assets.
+	Path   string
+	Source []byte
+}
+
+// Generate renders every asset and group in bundle into a single Go file
+// that registers them with the mewn runtime package from an init(). Callers
+// must have already run ResolveSources on bundle and ResolveFiles on each of
+// its Groups. mode controls asset encoding; metas may be nil to skip
+// metadata registration entirely (the --no-metadata build mode).
+func Generate(bundle *ReferencedAssets, mode EncodingMode, metas []*AssetMetadata) (*GeneratedFile, error) {
+	metaByName := make(map[string]*AssetMetadata, len(metas))
+	for _, m := range metas {
+		metaByName[m.Name] = m
+	}
+
+	var body bytes.Buffer
+	for _, asset := range bundle.Assets {
+		data, err := os.ReadFile(asset.SourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("generating asset %q: %w", asset.Name, err)
+		}
+		encoded, err := EncodeAsset(data, mode)
+		if err != nil {
+			return nil, fmt.Errorf("generating asset %q: %w", asset.Name, err)
+		}
+		WriteRegisterCall(&body, asset.Name, encoded)
+		if m, ok := metaByName[asset.Name]; ok {
+			WriteRegisterMetadataCall(&body, m)
+		}
+	}
+	for _, group := range bundle.Groups {
+		if err := WriteRegisterGroupCall(&body, group, mode); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by mewn. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", bundle.PackageName)
+	if len(metas) > 0 {
+		src.WriteString("import (\n\t\"github.com/leaanthony/mewn\"\n\t\"time\"\n)\n\n")
+	} else {
+		src.WriteString("import \"github.com/leaanthony/mewn\"\n\n")
+	}
+	src.WriteString("func init() {\n")
+	src.Write(body.Bytes())
+	src.WriteString("}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+
+	return &GeneratedFile{Path: filepath.Join(bundle.BaseDir, "mewn.go"), Source: formatted}, nil
+}
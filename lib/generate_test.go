@@ -0,0 +1,106 @@
+package lib
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateProducesValidGoSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), []byte("body{}"), 0644); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+
+	bundle := &ReferencedAssets{
+		PackageName: "assets",
+		BaseDir:     dir,
+		Assets: []*ReferencedAsset{
+			{Name: "style.css", AssetPath: "style.css"},
+		},
+	}
+	if err := bundle.ResolveSources(); err != nil {
+		t.Fatalf("ResolveSources failed: %v", err)
+	}
+
+	generated, err := Generate(bundle, EncodingRaw, nil)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if want := filepath.Join(dir, "mewn.go"); generated.Path != want {
+		t.Errorf("Path = %q, want %q", generated.Path, want)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, generated.Path, generated.Source, 0); err != nil {
+		t.Fatalf("Generate produced invalid Go source: %v\n%s", err, generated.Source)
+	}
+}
+
+func TestGenerateWithMetadataIncludesTimeImport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), []byte("body{}"), 0644); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+
+	bundle := &ReferencedAssets{
+		PackageName: "assets",
+		BaseDir:     dir,
+		Assets: []*ReferencedAsset{
+			{Name: "style.css", AssetPath: "style.css"},
+		},
+	}
+	if err := bundle.ResolveSources(); err != nil {
+		t.Fatalf("ResolveSources failed: %v", err)
+	}
+
+	metas, err := bundle.BuildMetadata()
+	if err != nil {
+		t.Fatalf("BuildMetadata failed: %v", err)
+	}
+
+	generated, err := Generate(bundle, EncodingRaw, metas)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, generated.Path, generated.Source, 0); err != nil {
+		t.Fatalf("Generate produced invalid Go source: %v\n%s", err, generated.Source)
+	}
+}
+
+func TestGenerateWithGroup(t *testing.T) {
+	dir := t.TempDir()
+	webDir := filepath.Join(dir, "web")
+	if err := os.MkdirAll(webDir, 0755); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(webDir, "index.html"), []byte("<h1>hi</h1>"), 0644); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+
+	group := &Group{Name: "webGroup", LocalPath: "./web", FullPath: webDir}
+	if err := group.ResolveFiles(); err != nil {
+		t.Fatalf("ResolveFiles failed: %v", err)
+	}
+
+	bundle := &ReferencedAssets{
+		PackageName: "assets",
+		BaseDir:     dir,
+		Groups:      []*Group{group},
+	}
+
+	generated, err := Generate(bundle, EncodingRaw, nil)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, generated.Path, generated.Source, 0); err != nil {
+		t.Fatalf("Generate produced invalid Go source: %v\n%s", err, generated.Source)
+	}
+}
@@ -0,0 +1,83 @@
+package lib
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// WriteByteLiteral writes data as a Go double-quoted string literal made up
+// entirely of \x-escaped bytes, suitable for embedding in generated source
+// as []byte("..."). Unlike a decimal []byte{1, 2, ...} literal, this is
+// both null-byte safe and considerably cheaper for the compiler to parse.
+func WriteByteLiteral(buf *bytes.Buffer, data []byte) {
+	buf.WriteByte('"')
+	for _, b := range data {
+		fmt.Fprintf(buf, "\\x%02x", b)
+	}
+	buf.WriteByte('"')
+}
+
+// WriteRegisterCall writes a single `mewn.Register(name, []byte("..."),
+// compressed)` statement for the given encoded asset, suitable for
+// inclusion in a generated file's init() function.
+func WriteRegisterCall(buf *bytes.Buffer, name string, encoded *EncodedAsset) {
+	fmt.Fprintf(buf, "\tmewn.Register(%q, []byte(", name)
+	WriteByteLiteral(buf, encoded.Data)
+	fmt.Fprintf(buf, "), %v)\n", encoded.Compressed)
+}
+
+// WriteRegisterMetadataCall writes a single `mewn.RegisterMetadata(name,
+// mode, time.Date(...), sum)` statement for the given asset metadata,
+// suitable for inclusion in a generated file's init() function.
+func WriteRegisterMetadataCall(buf *bytes.Buffer, m *AssetMetadata) {
+	t := m.ModTime.UTC()
+	fmt.Fprintf(buf, "\tmewn.RegisterMetadata(%q, 0%o, time.Date(%d, %d, %d, %d, %d, %d, %d, time.UTC), [32]byte{",
+		m.Name, m.Mode, t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+	for i, b := range m.Sum {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(buf, "0x%02x", b)
+	}
+	buf.WriteString("})\n")
+}
+
+// WriteRegisterGroupCall reads every file group.Files lists (relative to
+// group.FullPath), encodes each according to mode, and writes a single
+// `mewn.RegisterGroup(localPath, mewn.NewGroup(...))` statement that embeds
+// them all. Files are emitted in sorted order so the generated output is
+// reproducible.
+func WriteRegisterGroupCall(buf *bytes.Buffer, group *Group, mode EncodingMode) error {
+	names := append([]string(nil), group.Files...)
+	sort.Strings(names)
+
+	fmt.Fprintf(buf, "\tmewn.RegisterGroup(%q, mewn.NewGroup(map[string][]byte{\n", group.LocalPath)
+	var compressed []string
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(group.FullPath, name))
+		if err != nil {
+			return fmt.Errorf("generating group %q: %w", group.LocalPath, err)
+		}
+		encoded, err := EncodeAsset(data, mode)
+		if err != nil {
+			return fmt.Errorf("generating group %q: %w", group.LocalPath, err)
+		}
+
+		fmt.Fprintf(buf, "\t\t%q: []byte(", name)
+		WriteByteLiteral(buf, encoded.Data)
+		buf.WriteString("),\n")
+
+		if encoded.Compressed {
+			compressed = append(compressed, name)
+		}
+	}
+	buf.WriteString("\t}, map[string]bool{\n")
+	for _, name := range compressed {
+		fmt.Fprintf(buf, "\t\t%q: true,\n", name)
+	}
+	buf.WriteString("\t}))\n")
+	return nil
+}
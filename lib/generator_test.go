@@ -0,0 +1,50 @@
+package lib
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+func TestWriteByteLiteralRoundTrips(t *testing.T) {
+	data := []byte{0x00, 0x01, 0xff, '"', '\\', '\n', 'a', 'b', 'c'}
+
+	var buf bytes.Buffer
+	WriteByteLiteral(&buf, data)
+
+	expr, err := parser.ParseExprFrom(token.NewFileSet(), "literal.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("generated literal did not parse as a Go expression: %v", err)
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		t.Fatalf("expected a string literal, got %T", expr)
+	}
+
+	unquoted, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		t.Fatalf("generated literal failed to unquote: %v", err)
+	}
+
+	if !bytes.Equal([]byte(unquoted), data) {
+		t.Fatalf("round-tripped bytes %v, want %v", []byte(unquoted), data)
+	}
+}
+
+func TestWriteRegisterCallProducesValidGoSource(t *testing.T) {
+	encoded := &EncodedAsset{Data: []byte{0x00, 'h', 'i'}, Compressed: true}
+
+	var buf bytes.Buffer
+	WriteRegisterCall(&buf, "style.css", encoded)
+
+	src := "package generated\n\nfunc init() {\n" + buf.String() + "}\n"
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "generated.go", src, 0); err != nil {
+		t.Fatalf("WriteRegisterCall produced invalid Go source: %v\n%s", err, src)
+	}
+}
@@ -0,0 +1,98 @@
+package lib
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AssetMetadata is the per-asset metadata the generator optionally embeds
+// alongside an asset's contents: its original file mode, modification time,
+// and a sha256 digest of its uncompressed contents.
+type AssetMetadata struct {
+	Name    string
+	Mode    os.FileMode
+	ModTime time.Time
+	Sum     [32]byte
+}
+
+// BuildMetadata computes metadata for every asset in r, which must already
+// have had its sources resolved via ResolveSources. Results are sorted by
+// name and ModTime is normalised to UTC, so the generated output is
+// reproducible regardless of build machine or timezone.
+func (r *ReferencedAssets) BuildMetadata() ([]*AssetMetadata, error) {
+	metas := make([]*AssetMetadata, 0, len(r.Assets))
+
+	for _, asset := range r.Assets {
+		if asset.Info == nil {
+			return nil, fmt.Errorf("asset %q has no resolved source; call ResolveSources first", asset.Name)
+		}
+
+		data, err := os.ReadFile(asset.SourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		metas = append(metas, &AssetMetadata{
+			Name:    asset.Name,
+			Mode:    asset.Info.Mode(),
+			ModTime: asset.Info.ModTime().UTC(),
+			Sum:     sha256.Sum256(data),
+		})
+	}
+
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Name < metas[j].Name })
+	return metas, nil
+}
+
+// WriteSumFile writes metas to w in a stable, sha256sum-style format - one
+// "<hex digest>  <name>" line per asset, sorted by name (BuildMetadata
+// already returns them that way). The `mewn generate` command writes this
+// alongside the generated file, and `mewn verify` reads it back with
+// ReadSumFile to check a tree's sources against the last build without
+// needing to run the built binary.
+func WriteSumFile(w io.Writer, metas []*AssetMetadata) error {
+	for _, m := range metas {
+		if _, err := fmt.Fprintf(w, "%x  %s\n", m.Sum, m.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSumFile parses a file written by WriteSumFile back into
+// AssetMetadata values carrying only Name and Sum, which is all Verify
+// needs.
+func ReadSumFile(r io.Reader) ([]*AssetMetadata, error) {
+	var metas []*AssetMetadata
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "  ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed sum line %q", line)
+		}
+
+		sum, err := hex.DecodeString(fields[0])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("malformed digest in line %q", line)
+		}
+
+		var m AssetMetadata
+		m.Name = fields[1]
+		copy(m.Sum[:], sum)
+		metas = append(metas, &m)
+	}
+	return metas, scanner.Err()
+}
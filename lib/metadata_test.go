@@ -0,0 +1,142 @@
+package lib
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testBundle(t *testing.T) *ReferencedAssets {
+	t.Helper()
+
+	baseDir, err := filepath.Abs("testdata/web")
+	if err != nil {
+		t.Fatalf("failed to resolve testdata path: %v", err)
+	}
+
+	return &ReferencedAssets{
+		BaseDir: baseDir,
+		Assets: []*ReferencedAsset{
+			{Name: "index.html", AssetPath: "index.html"},
+			{Name: "style.css", AssetPath: "css/style.css"},
+		},
+	}
+}
+
+func TestResolveSourcesAndBuildMetadata(t *testing.T) {
+	bundle := testBundle(t)
+
+	if err := bundle.ResolveSources(); err != nil {
+		t.Fatalf("ResolveSources returned an error: %v", err)
+	}
+
+	for _, asset := range bundle.Assets {
+		if asset.SourcePath == "" || asset.Info == nil {
+			t.Fatalf("asset %q was not resolved: %+v", asset.Name, asset)
+		}
+	}
+
+	metas, err := bundle.BuildMetadata()
+	if err != nil {
+		t.Fatalf("BuildMetadata returned an error: %v", err)
+	}
+
+	if len(metas) != 2 {
+		t.Fatalf("expected 2 metadata entries, got %d", len(metas))
+	}
+	// Sorted by name.
+	if metas[0].Name != "index.html" || metas[1].Name != "style.css" {
+		t.Fatalf("expected metadata sorted by name, got %q, %q", metas[0].Name, metas[1].Name)
+	}
+	if metas[0].ModTime.Location().String() != "UTC" {
+		t.Fatalf("expected ModTime to be normalised to UTC, got %v", metas[0].ModTime.Location())
+	}
+}
+
+func TestBuildMetadataRequiresResolvedSources(t *testing.T) {
+	bundle := testBundle(t)
+
+	if _, err := bundle.BuildMetadata(); err == nil {
+		t.Fatalf("expected an error when sources haven't been resolved")
+	}
+}
+
+func TestSumFileRoundTrips(t *testing.T) {
+	bundle := testBundle(t)
+	if err := bundle.ResolveSources(); err != nil {
+		t.Fatalf("ResolveSources returned an error: %v", err)
+	}
+	metas, err := bundle.BuildMetadata()
+	if err != nil {
+		t.Fatalf("BuildMetadata returned an error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSumFile(&buf, metas); err != nil {
+		t.Fatalf("WriteSumFile returned an error: %v", err)
+	}
+
+	got, err := ReadSumFile(&buf)
+	if err != nil {
+		t.Fatalf("ReadSumFile returned an error: %v", err)
+	}
+	if len(got) != len(metas) {
+		t.Fatalf("expected %d entries, got %d", len(metas), len(got))
+	}
+	for i, want := range metas {
+		if got[i].Name != want.Name || got[i].Sum != want.Sum {
+			t.Errorf("entry %d = %+v, want name %q sum %x", i, got[i], want.Name, want.Sum)
+		}
+	}
+
+	// ReadSumFile only recovers Name and Sum - Mode/ModTime are zero.
+	if !reflect.DeepEqual(got[0].ModTime, got[0].ModTime.UTC()) || !got[0].ModTime.IsZero() {
+		t.Errorf("expected a zero ModTime from ReadSumFile, got %v", got[0].ModTime)
+	}
+}
+
+func TestVerifyDetectsStaleAsset(t *testing.T) {
+	bundle := testBundle(t)
+	if err := bundle.ResolveSources(); err != nil {
+		t.Fatalf("ResolveSources returned an error: %v", err)
+	}
+	metas, err := bundle.BuildMetadata()
+	if err != nil {
+		t.Fatalf("BuildMetadata returned an error: %v", err)
+	}
+
+	results := Verify(metas, bundle)
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("unexpected error verifying %q: %v", result.Name, result.Err)
+		}
+		if result.Stale {
+			t.Fatalf("expected %q to be up to date immediately after building metadata", result.Name)
+		}
+	}
+
+	// Modify a source file on disk and confirm Verify flags it as stale.
+	indexPath := filepath.Join(bundle.BaseDir, "index.html")
+	original, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	t.Cleanup(func() { os.WriteFile(indexPath, original, 0644) })
+
+	if err := os.WriteFile(indexPath, append(original, '!'), 0644); err != nil {
+		t.Fatalf("failed to modify fixture: %v", err)
+	}
+
+	results = Verify(metas, bundle)
+	var sawStaleIndex bool
+	for _, result := range results {
+		if result.Name == "index.html" {
+			sawStaleIndex = result.Stale
+		}
+	}
+	if !sawStaleIndex {
+		t.Fatalf("expected index.html to be flagged as stale after modification")
+	}
+}
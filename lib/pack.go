@@ -0,0 +1,41 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ResolveSources stats each asset's source file and records its absolute
+// path and os.FileInfo, ready for the encoding and metadata passes. An
+// asset's source is resolved relative to its group's FullPath, or to the
+// bundle's BaseDir for assets referenced directly (not via a group).
+func (r *ReferencedAssets) ResolveSources() error {
+	for _, asset := range r.Assets {
+		if err := asset.resolveSource(r.BaseDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ReferencedAsset) resolveSource(baseDir string) error {
+	dir := baseDir
+	if a.Group != nil {
+		dir = a.Group.FullPath
+	}
+
+	abs, err := filepath.Abs(filepath.Join(dir, a.AssetPath))
+	if err != nil {
+		return fmt.Errorf("resolving source for asset %q: %w", a.Name, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("resolving source for asset %q: %w", a.Name, err)
+	}
+
+	a.SourcePath = abs
+	a.Info = info
+	return nil
+}
@@ -0,0 +1,100 @@
+package lib
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadTypeInfo type-checks the package(s) containing filenames using
+// golang.org/x/tools/go/packages, returning the re-parsed syntax tree and
+// type info for each file, keyed by absolute path. Callers should fall back
+// to a literal-only parse when this returns an error, since many real-world
+// trees don't type-check cleanly (vendored code, build-tag-gated files,
+// packages still mid-edit).
+func loadTypeInfo(filenames []string) (map[string]*ast.File, map[string]*types.Info, error) {
+	patterns := make([]string, len(filenames))
+	for i, filename := range filenames {
+		abs, err := filepath.Abs(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+		patterns[i] = "file=" + abs
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string]*ast.File)
+	infos := make(map[string]*types.Info)
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Pos()).Filename
+			files[name] = file
+			infos[name] = pkg.TypesInfo
+		}
+	}
+	return files, infos, nil
+}
+
+// resolveConstantString attempts to constant-fold expr to a string using the
+// type-checker results in info. It understands plain identifiers (consts),
+// string concatenation, and calls to path.Join/filepath.Join whose arguments
+// are all themselves constant strings.
+func resolveConstantString(info *types.Info, expr ast.Expr) (string, bool) {
+	if info == nil {
+		return "", false
+	}
+
+	if call, ok := expr.(*ast.CallExpr); ok {
+		return resolveJoinCall(info, call)
+	}
+
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// resolveJoinCall folds path.Join/filepath.Join calls whose arguments are
+// all constant strings (directly, or transitively via resolveConstantString).
+func resolveJoinCall(info *types.Info, call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok || sel.Sel.Name != "Join" {
+		return "", false
+	}
+	if pkgIdent.Name != "path" && pkgIdent.Name != "filepath" {
+		return "", false
+	}
+
+	parts := make([]string, 0, len(call.Args))
+	for _, arg := range call.Args {
+		value, ok := resolveConstantString(info, arg)
+		if !ok {
+			return "", false
+		}
+		parts = append(parts, value)
+	}
+
+	if pkgIdent.Name == "path" {
+		return path.Join(parts...), true
+	}
+	return filepath.Join(parts...), true
+}
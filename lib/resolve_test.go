@@ -0,0 +1,102 @@
+package lib
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// checkSnippet type-checks src (a complete Go file) and returns its AST
+// along with the resulting type info, without touching any on-disk module.
+func checkSnippet(t *testing.T, src string) (*ast.File, *types.Info) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "snippet.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse snippet: %v", err)
+	}
+
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("snippet", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("failed to type-check snippet: %v", err)
+	}
+
+	return file, info
+}
+
+// findAssignValue returns the RHS expression of the package-level var/const
+// declaration named name.
+func findAssignValue(file *ast.File, name string) ast.Expr {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, n := range valueSpec.Names {
+				if n.Name == name {
+					return valueSpec.Values[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func TestResolveConstantStringIdentifier(t *testing.T) {
+	file, info := checkSnippet(t, `package snippet
+const assetsDir = "web/dist"
+var target = assetsDir
+`)
+
+	value, ok := resolveConstantString(info, findAssignValue(file, "target"))
+	if !ok || value != "web/dist" {
+		t.Fatalf("expected (\"web/dist\", true), got (%q, %v)", value, ok)
+	}
+}
+
+func TestResolveConstantStringConcatenation(t *testing.T) {
+	file, info := checkSnippet(t, `package snippet
+const assetsDir = "web/dist"
+var target = assetsDir + "/index.html"
+`)
+
+	value, ok := resolveConstantString(info, findAssignValue(file, "target"))
+	if !ok || value != "web/dist/index.html" {
+		t.Fatalf("expected (\"web/dist/index.html\", true), got (%q, %v)", value, ok)
+	}
+}
+
+func TestResolveConstantStringFilepathJoin(t *testing.T) {
+	file, info := checkSnippet(t, `package snippet
+import "path/filepath"
+const assetsDir = "web/dist"
+var target = filepath.Join(assetsDir, "index.html")
+`)
+
+	value, ok := resolveConstantString(info, findAssignValue(file, "target"))
+	want := "web/dist/index.html"
+	if !ok || value != want {
+		t.Fatalf("expected (%q, true), got (%q, %v)", want, value, ok)
+	}
+}
+
+func TestResolveConstantStringNonConstant(t *testing.T) {
+	file, info := checkSnippet(t, `package snippet
+import "os"
+var target = os.Getenv("ASSET_PATH")
+`)
+
+	if _, ok := resolveConstantString(info, findAssignValue(file, "target")); ok {
+		t.Fatalf("expected a non-constant expression to fail to resolve")
+	}
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import "github.com/leaanthony/mewn"
+
+// Top-level var assignment
+var css = mewn.String("style.css")
+
+// Grouped var block
+var (
+	webGroup = mewn.Group("./web")
+	index    = webGroup.String("index.html")
+)
+
+// const-adjacent declaration: the const itself isn't a mewn call, but it
+// sits in the same GenDecl shape the scanner needs to walk correctly.
+const buildTag = "release"
+
+var favicon = mewn.Bytes("favicon.ico")
+
+func init() {
+	// Discarded return value - still needs to be detected.
+	mewn.Bytes("warmup.bin")
+}
@@ -0,0 +1,8 @@
+package testdata
+
+import "github.com/leaanthony/mewn"
+
+var webGroupFiltered = mewn.Group("./web",
+	mewn.Exclude("*.map", "node_modules/**"),
+	mewn.Include("*.html", "*.css", "*.js"),
+)
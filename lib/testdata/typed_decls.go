@@ -0,0 +1,9 @@
+package testdata
+
+import "github.com/leaanthony/mewn"
+
+// assetsDir only resolves via the typed (packages.Load) pass below; it is
+// not a literal, so ParseCallExpr alone can never see it.
+const assetsDir = "web/dist"
+
+var typedIndex = mewn.MustString(assetsDir + "/index.html")
@@ -0,0 +1,45 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+)
+
+// VerifyResult describes the outcome of comparing one embedded asset's
+// digest against its current on-disk contents.
+type VerifyResult struct {
+	Name  string
+	Stale bool
+	Err   error
+}
+
+// Verify re-hashes each asset's on-disk source (resolved via
+// ResolveSources) and compares it against metas, flagging any asset whose
+// embedded digest no longer matches what's on disk - i.e. the generated
+// file is stale and needs regenerating. This backs the `mewn verify` CLI
+// subcommand, used to catch stale generated files in CI.
+func Verify(metas []*AssetMetadata, r *ReferencedAssets) []VerifyResult {
+	want := make(map[string][32]byte, len(metas))
+	for _, m := range metas {
+		want[m.Name] = m.Sum
+	}
+
+	results := make([]VerifyResult, 0, len(r.Assets))
+	for _, asset := range r.Assets {
+		sum, ok := want[asset.Name]
+		if !ok {
+			results = append(results, VerifyResult{Name: asset.Name, Err: fmt.Errorf("no embedded metadata for asset %q", asset.Name)})
+			continue
+		}
+
+		data, err := os.ReadFile(asset.SourcePath)
+		if err != nil {
+			results = append(results, VerifyResult{Name: asset.Name, Err: err})
+			continue
+		}
+
+		results = append(results, VerifyResult{Name: asset.Name, Stale: sha256.Sum256(data) != sum})
+	}
+	return results
+}
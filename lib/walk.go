@@ -0,0 +1,36 @@
+package lib
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// ResolveFiles walks the group's FullPath directory, recording every file
+// that passes the group's include/exclude filters. The resulting relative,
+// slash-separated paths are stored on the group so the generator has enough
+// information to emit a directory tree rather than a flat name-to-bytes map.
+func (g *Group) ResolveFiles() error {
+	g.Files = nil
+
+	return filepath.WalkDir(g.FullPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(g.FullPath, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !g.MatchesFilters(rel) {
+			return nil
+		}
+
+		g.Files = append(g.Files, rel)
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package lib
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestResolveFilesNoFilters(t *testing.T) {
+	full, err := filepath.Abs("testdata/web")
+	if err != nil {
+		t.Fatalf("failed to resolve testdata path: %v", err)
+	}
+	g := &Group{FullPath: full}
+
+	if err := g.ResolveFiles(); err != nil {
+		t.Fatalf("ResolveFiles returned an error: %v", err)
+	}
+
+	want := []string{"app.js", "app.js.map", "css/style.css", "index.html", "node_modules/foo/index.js"}
+	got := append([]string(nil), g.Files...)
+	sort.Strings(got)
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i, name := range want {
+		if got[i] != name {
+			t.Errorf("Files[%d] = %q, want %q", i, got[i], name)
+		}
+	}
+}
+
+func TestResolveFilesWithFilters(t *testing.T) {
+	full, err := filepath.Abs("testdata/web")
+	if err != nil {
+		t.Fatalf("failed to resolve testdata path: %v", err)
+	}
+	g := &Group{
+		FullPath: full,
+		Include:  []string{"*.html", "*.css", "*.js", "**/*.css"},
+		Exclude:  []string{"*.map", "node_modules/**"},
+	}
+
+	if err := g.ResolveFiles(); err != nil {
+		t.Fatalf("ResolveFiles returned an error: %v", err)
+	}
+
+	want := []string{"app.js", "css/style.css", "index.html"}
+	got := append([]string(nil), g.Files...)
+	sort.Strings(got)
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i, name := range want {
+		if got[i] != name {
+			t.Errorf("Files[%d] = %q, want %q", i, got[i], name)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package mewn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestInfoAndSumWithMetadata(t *testing.T) {
+	Register("metadata.txt", []byte("hello"), false)
+	sum := sha256.Sum256([]byte("hello"))
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	RegisterMetadata("metadata.txt", 0644, modTime, sum)
+
+	info, err := Info("metadata.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode() != 0644 {
+		t.Errorf("Mode() = %v, want %v", info.Mode(), os.FileMode(0644))
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("ModTime() = %v, want %v", info.ModTime(), modTime)
+	}
+	if info.Size() != int64(len("hello")) {
+		t.Errorf("Size() = %d, want %d", info.Size(), len("hello"))
+	}
+
+	got := Sum("metadata.txt")
+	if !bytes.Equal(got, sum[:]) {
+		t.Errorf("Sum() = %x, want %x", got, sum)
+	}
+}
+
+func TestInfoWithoutMetadataReturnsErrNoMetadata(t *testing.T) {
+	Register("no-metadata.txt", []byte("hello"), false)
+
+	if _, err := Info("no-metadata.txt"); !errors.Is(err, ErrNoMetadata) {
+		t.Fatalf("expected ErrNoMetadata, got %v", err)
+	}
+}
+
+func TestSumWithoutMetadataReturnsNil(t *testing.T) {
+	Register("no-sum.txt", []byte("hello"), false)
+
+	if got := Sum("no-sum.txt"); got != nil {
+		t.Fatalf("expected nil, got %x", got)
+	}
+}
+
+func TestSumUnknownAssetReturnsNil(t *testing.T) {
+	if got := Sum("does-not-exist"); got != nil {
+		t.Fatalf("expected nil, got %x", got)
+	}
+}
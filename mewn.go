@@ -0,0 +1,178 @@
+// Package mewn is the runtime support library for code generated by the
+// mewn tool. Generated files register their embedded assets with this
+// package via Register and user code reads them back via Bytes/String.
+package mewn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// asset holds one registered asset's raw (possibly still gzip-compressed)
+// bytes, plus the memoized, fully-resolved result of decompressing it.
+type asset struct {
+	data       []byte
+	compressed bool
+	meta       *assetMeta
+
+	once     sync.Once
+	resolved []byte
+	err      error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*asset{}
+)
+
+// Register makes an embedded asset available under name. It is called from
+// the init() functions of generated files and is not meant to be called
+// directly by user code.
+func Register(name string, data []byte, compressed bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	a := &asset{data: data, compressed: compressed}
+	if prev, ok := registry[name]; ok {
+		a.meta = prev.meta
+	}
+	registry[name] = a
+}
+
+func lookup(name string) (*asset, error) {
+	registryMu.RLock()
+	a, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("mewn: no asset named %q", name)
+	}
+	return a, nil
+}
+
+// resolve decompresses a, memoizing the result so repeated calls don't pay
+// the decompression cost more than once.
+func (a *asset) resolve() ([]byte, error) {
+	a.once.Do(func() {
+		if !a.compressed {
+			a.resolved = a.data
+			return
+		}
+		r, err := gzip.NewReader(bytes.NewReader(a.data))
+		if err != nil {
+			a.err = err
+			return
+		}
+		defer r.Close()
+		a.resolved, a.err = ioutil.ReadAll(r)
+	})
+	return a.resolved, a.err
+}
+
+// Bytes returns the contents of the named asset, panicking if it is unknown
+// or fails to decompress - both of which mean the binary was built from a
+// stale or broken generated file, not something callers can recover from.
+// MustBytes is kept as an alias for call sites that prefer to spell that out.
+func Bytes(name string) []byte {
+	a, err := lookup(name)
+	if err != nil {
+		panic(err)
+	}
+	b, err := a.resolve()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustBytes is an alias for Bytes.
+func MustBytes(name string) []byte {
+	return Bytes(name)
+}
+
+// String returns the contents of the named asset as a string. See Bytes for
+// the panic behavior.
+func String(name string) string {
+	return string(Bytes(name))
+}
+
+// MustString is an alias for String.
+func MustString(name string) string {
+	return String(name)
+}
+
+// ErrNoMetadata is returned by Info when a binary was built with
+// --no-metadata, so no per-asset mode/mtime/digest was embedded.
+var ErrNoMetadata = errors.New("mewn: asset metadata was not embedded (built with --no-metadata)")
+
+// assetMeta is the optional per-asset metadata a generated file registers
+// via RegisterMetadata.
+type assetMeta struct {
+	mode    os.FileMode
+	modTime time.Time
+	sum     [32]byte
+}
+
+// RegisterMetadata attaches metadata to a previously (or subsequently)
+// registered asset. It is called from the init() functions of generated
+// files built without --no-metadata, and is not meant to be called
+// directly by user code.
+func RegisterMetadata(name string, mode os.FileMode, modTime time.Time, sum [32]byte) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	a, ok := registry[name]
+	if !ok {
+		a = &asset{}
+		registry[name] = a
+	}
+	a.meta = &assetMeta{mode: mode, modTime: modTime, sum: sum}
+}
+
+// assetInfo is the os.FileInfo returned by Info.
+type assetInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi assetInfo) Name() string       { return fi.name }
+func (fi assetInfo) Size() int64        { return fi.size }
+func (fi assetInfo) Mode() os.FileMode  { return fi.mode }
+func (fi assetInfo) ModTime() time.Time { return fi.modTime }
+func (fi assetInfo) IsDir() bool        { return false }
+func (fi assetInfo) Sys() interface{}   { return nil }
+
+// Info returns the named asset's original file mode and modification time,
+// as captured at build time. It returns ErrNoMetadata if the binary was
+// built with --no-metadata.
+func Info(name string) (os.FileInfo, error) {
+	a, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if a.meta == nil {
+		return nil, ErrNoMetadata
+	}
+	data, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return assetInfo{name: name, size: int64(len(data)), mode: a.meta.mode, modTime: a.meta.modTime}, nil
+}
+
+// Sum returns the sha256 digest of the named asset's uncompressed contents,
+// as captured at build time, or nil if the asset is unknown or the binary
+// was built with --no-metadata.
+func Sum(name string) []byte {
+	a, err := lookup(name)
+	if err != nil || a.meta == nil {
+		return nil
+	}
+	sum := a.meta.sum
+	return sum[:]
+}
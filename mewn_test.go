@@ -0,0 +1,57 @@
+package mewn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+func TestBytesRaw(t *testing.T) {
+	Register("raw.txt", []byte("hello"), false)
+
+	got := Bytes("raw.txt")
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+}
+
+func TestBytesGzip(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte("compressed contents")); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to prepare fixture: %v", err)
+	}
+
+	Register("gz.txt", buf.Bytes(), true)
+
+	got := String("gz.txt")
+	if got != "compressed contents" {
+		t.Fatalf("got %q, want %q", got, "compressed contents")
+	}
+
+	// A second read should return the same memoized result.
+	if again := String("gz.txt"); again != got {
+		t.Fatalf("second read diverged: got %q, want %q", again, got)
+	}
+}
+
+func TestBytesPanicsOnUnknownAsset(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Bytes to panic for an unregistered asset")
+		}
+	}()
+	Bytes("does-not-exist")
+}
+
+func TestMustBytesPanicsOnUnknownAsset(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected MustBytes to panic for an unregistered asset")
+		}
+	}()
+	MustBytes("does-not-exist")
+}